@@ -60,6 +60,13 @@ func DefaultPrivacyConfig() *PrivacyConfig {
 type Logger struct {
 	storage Storage
 	privacy *PrivacyConfig
+	costs   CostEstimator
+}
+
+// CostEstimator prices a request from its token usage. The bool result is
+// false when the provider/model has no known price.
+type CostEstimator interface {
+	EstimateCost(providerID, modelName string, promptTokens, completionTokens int64) (float64, bool)
 }
 
 // Storage interface for persisting logs
@@ -107,6 +114,12 @@ func NewLogger(storage Storage, privacy *PrivacyConfig) *Logger {
 	}
 }
 
+// SetCostEstimator sets the pricing source used to fill in CostUSD for
+// requests logged without a cost.
+func (l *Logger) SetCostEstimator(costs CostEstimator) {
+	l.costs = costs
+}
+
 // LogRequest logs an API request with privacy controls
 func (l *Logger) LogRequest(ctx context.Context, log *RequestLog) error {
 	// Apply privacy filters
@@ -140,6 +153,17 @@ func (l *Logger) LogRequest(ctx context.Context, log *RequestLog) error {
 		log.Timestamp = time.Now()
 	}
 
+	// Price the request from the catalog so budget alerts see real costs
+	if log.CostUSD == 0 && l.costs != nil {
+		prompt, completion := log.PromptTokens, log.CompletionTokens
+		if prompt == 0 && completion == 0 {
+			prompt = log.TotalTokens
+		}
+		if cost, ok := l.costs.EstimateCost(log.ProviderID, log.ModelName, prompt, completion); ok {
+			log.CostUSD = cost
+		}
+	}
+
 	return l.storage.SaveLog(ctx, log)
 }
 
@@ -163,6 +163,35 @@ func TestLogRequest_BodyTruncation(t *testing.T) {
 	}
 }
 
+type fixedCostEstimator struct{ per1K float64 }
+
+func (f fixedCostEstimator) EstimateCost(providerID, modelName string, promptTokens, completionTokens int64) (float64, bool) {
+	if providerID == "unknown" {
+		return 0, false
+	}
+	return float64(promptTokens+completionTokens) * f.per1K / 1000, true
+}
+
+func TestLogRequest_CostEstimator(t *testing.T) {
+	storage := &MockStorage{}
+	logger := NewLogger(storage, nil)
+	logger.SetCostEstimator(fixedCostEstimator{per1K: 0.01})
+
+	_ = logger.LogRequest(context.Background(), &RequestLog{ProviderID: "openai", TotalTokens: 2000})
+	_ = logger.LogRequest(context.Background(), &RequestLog{ProviderID: "openai", TotalTokens: 2000, CostUSD: 1.5})
+	_ = logger.LogRequest(context.Background(), &RequestLog{ProviderID: "unknown", TotalTokens: 2000})
+
+	if got := storage.logs[0].CostUSD; got != 0.02 {
+		t.Errorf("Expected estimated cost 0.02, got %f", got)
+	}
+	if got := storage.logs[1].CostUSD; got != 1.5 {
+		t.Errorf("Explicit cost should be kept, got %f", got)
+	}
+	if got := storage.logs[2].CostUSD; got != 0 {
+		t.Errorf("Unknown provider should stay unpriced, got %f", got)
+	}
+}
+
 func TestCalculateCost(t *testing.T) {
 	tests := []struct {
 		name          string
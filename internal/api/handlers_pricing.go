@@ -0,0 +1,107 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/jordanhubbard/loom/internal/pricing"
+)
+
+// pricingCatalog returns the catalog owned by the Loom instance, falling back
+// to the process-wide default when running without one.
+func (s *Server) pricingCatalog() *pricing.Catalog {
+	if s.app != nil {
+		if c := s.app.GetPricingCatalog(); c != nil {
+			return c
+		}
+	}
+	return pricing.Default()
+}
+
+// handlePricing handles GET /api/v1/pricing
+func (s *Server) handlePricing(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		s.respondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	catalog := s.pricingCatalog()
+	prices := catalog.List()
+	response := map[string]interface{}{
+		"prices":       prices,
+		"count":        len(prices),
+		"catalog_path": catalog.Path(),
+	}
+	if updated := catalog.LastUpdated(); !updated.IsZero() {
+		response["last_updated"] = updated
+	}
+	s.respondJSON(w, http.StatusOK, response)
+}
+
+// handlePricingOverrides handles PUT/POST/DELETE /api/v1/pricing/overrides.
+// DELETE takes provider_id and model_name query parameters.
+func (s *Server) handlePricingOverrides(w http.ResponseWriter, r *http.Request) {
+	catalog := s.pricingCatalog()
+
+	switch r.Method {
+	case http.MethodPut, http.MethodPost:
+		var price pricing.Price
+		if err := s.parseJSON(r, &price); err != nil {
+			s.respondError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+		saved, err := catalog.SetOverride(price)
+		if err != nil {
+			if errors.Is(err, pricing.ErrInvalidPrice) {
+				s.respondError(w, http.StatusBadRequest, err.Error())
+			} else {
+				s.respondError(w, http.StatusInternalServerError, "Failed to persist override: "+err.Error())
+			}
+			return
+		}
+		s.respondJSON(w, http.StatusOK, saved)
+
+	case http.MethodDelete:
+		providerID := r.URL.Query().Get("provider_id")
+		modelName := r.URL.Query().Get("model_name")
+		if providerID == "" || modelName == "" {
+			s.respondError(w, http.StatusBadRequest, "provider_id and model_name are required")
+			return
+		}
+		removed, err := catalog.DeleteOverride(providerID, modelName)
+		if err != nil {
+			s.respondError(w, http.StatusInternalServerError, "Failed to persist overrides: "+err.Error())
+			return
+		}
+		if !removed {
+			s.respondError(w, http.StatusNotFound, "Override not found")
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		s.respondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+// handlePricingRefresh handles POST /api/v1/pricing/refresh
+func (s *Server) handlePricingRefresh(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		s.respondError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	catalog := s.pricingCatalog()
+	if catalog.Path() == "" {
+		s.respondError(w, http.StatusBadRequest, "No pricing catalog file configured")
+		return
+	}
+	if err := catalog.LoadFile(catalog.Path()); err != nil {
+		s.respondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	s.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"count":        len(catalog.List()),
+		"last_updated": catalog.LastUpdated(),
+	})
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/jordanhubbard/loom/internal/keymanager"
 	"github.com/jordanhubbard/loom/internal/logging"
 	"github.com/jordanhubbard/loom/internal/metrics"
+	"github.com/jordanhubbard/loom/internal/pricing"
 	"github.com/jordanhubbard/loom/pkg/config"
 	"github.com/jordanhubbard/loom/pkg/models"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
@@ -52,6 +53,7 @@ func NewServer(arb *loom.Loom, km *keymanager.KeyManager, am *auth.Manager, cfg
 		storage, err := analytics.NewDatabaseStorage(arb.GetDatabase().DB())
 		if err == nil {
 			analyticsLogger = analytics.NewLogger(storage, analytics.DefaultPrivacyConfig())
+			analyticsLogger.SetCostEstimator(pricing.Default())
 		}
 	}
 
@@ -277,6 +279,11 @@ func (s *Server) SetupRoutes() http.Handler {
 	mux.HandleFunc("/api/v1/cache/optimize", s.handleCacheOptimize)
 	mux.HandleFunc("/api/v1/cache/recommendations", s.handleCacheRecommendations)
 
+	// Pricing catalog
+	mux.HandleFunc("/api/v1/pricing", s.handlePricing)
+	mux.HandleFunc("/api/v1/pricing/overrides", s.handlePricingOverrides)
+	mux.HandleFunc("/api/v1/pricing/refresh", s.handlePricingRefresh)
+
 	// Pattern analysis routes
 	mux.HandleFunc("/api/v1/patterns/analysis", s.handlePatternAnalysis)
 	mux.HandleFunc("/api/v1/patterns/expensive", s.handleExpensivePatterns)
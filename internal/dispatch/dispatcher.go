@@ -72,6 +72,7 @@ type Dispatcher struct {
 	escalator           Escalator
 	maxDispatchHops     int
 	loopDetector        *LoopDetector
+	review              ReviewConfig
 
 	mu     sync.RWMutex
 	status SystemStatus
@@ -635,9 +636,17 @@ func (d *Dispatcher) DispatchOnce(ctx context.Context, projectID string) (*Dispa
 
 	proj, _ := d.projects.GetProject(selectedProjectID)
 
+	// Reviewers get a fresh single-shot view of the work; the conversation
+	// session belongs to the author.
+	reviewRun := isReviewRun(candidate, ag.ID)
+	description := buildBeadDescription(candidate)
+	if reviewRun {
+		description = buildReviewDescription(candidate)
+	}
+
 	// Get or create conversation session for multi-turn conversation support
 	var conversationSession *models.ConversationContext
-	if d.db != nil {
+	if d.db != nil && !reviewRun {
 		var err error
 		conversationSession, err = d.getOrCreateConversationSession(candidate, selectedProjectID)
 		if err != nil {
@@ -651,7 +660,7 @@ func (d *Dispatcher) DispatchOnce(ctx context.Context, projectID string) (*Dispa
 
 	task := &worker.Task{
 		ID:                  fmt.Sprintf("task-%s-%d", candidate.ID, time.Now().UnixNano()),
-		Description:         buildBeadDescription(candidate),
+		Description:         description,
 		Context:             buildBeadContext(candidate, proj),
 		BeadID:              candidate.ID,
 		ProjectID:           selectedProjectID,
@@ -790,6 +799,8 @@ func (d *Dispatcher) DispatchOnce(ctx context.Context, projectID string) (*Dispa
 		updates["status"] = models.BeadStatusOpen
 		updates["assigned_to"] = triageAgent
 		log.Printf("[Dispatcher] Task failure loop for bead %s, reassigning to triage agent %s", candidate.ID, triageAgent)
+	} else {
+		d.applyReviewTransition(candidate, ag.ID, result.Response, result.LoopTerminalReason, ctxUpdates, updates)
 	}
 	if err := d.beads.UpdateBead(candidate.ID, updates); err != nil {
 		log.Printf("[Dispatcher] CRITICAL: Failed to update bead %s after task failure: %v", candidate.ID, err)
@@ -0,0 +1,305 @@
+package dispatch
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jordanhubbard/loom/pkg/models"
+)
+
+// Review states recorded in bead context under "review_state".
+const (
+	ReviewStatePending          = "pending"
+	ReviewStateChangesRequested = "changes_requested"
+	ReviewStateApproved         = "approved"
+	ReviewStateExhausted        = "exhausted"
+)
+
+// ReviewVerdict is the outcome a reviewer agent reports for a review round.
+type ReviewVerdict string
+
+const (
+	ReviewVerdictNone           ReviewVerdict = ""
+	ReviewVerdictApprove        ReviewVerdict = "approve"
+	ReviewVerdictRequestChanges ReviewVerdict = "request_changes"
+)
+
+const (
+	defaultReviewerRole    = "code-reviewer"
+	defaultMaxReviewRounds = 3
+	reviewOutputLimit      = 6000
+
+	reviewOutcomesContextKey = "review_outcomes"
+	reviewFeedbackContextKey = "review_feedback"
+	reviewRoundContextKey    = "review_round"
+	reviewStateContextKey    = "review_state"
+	reviewAuthorContextKey   = "review_author_id"
+	reviewReviewerContextKey = "review_reviewer_id"
+)
+
+// ReviewConfig controls the author/reviewer loop that runs after an author
+// agent signals DONE on a bead.
+type ReviewConfig struct {
+	Enabled      bool
+	ReviewerRole string
+	MaxRounds    int
+}
+
+// ReviewOutcome is a single review round recorded on the bead.
+type ReviewOutcome struct {
+	Round      int           `json:"round"`
+	AuthorID   string        `json:"author_id"`
+	ReviewerID string        `json:"reviewer_id"`
+	Verdict    ReviewVerdict `json:"verdict"`
+	Feedback   string        `json:"feedback,omitempty"`
+	ReviewedAt time.Time     `json:"reviewed_at"`
+}
+
+// SetReviewConfig enables or reconfigures the multi-agent review stage.
+func (d *Dispatcher) SetReviewConfig(cfg ReviewConfig) {
+	if cfg.ReviewerRole == "" {
+		cfg.ReviewerRole = defaultReviewerRole
+	}
+	if cfg.MaxRounds <= 0 {
+		cfg.MaxRounds = defaultMaxReviewRounds
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.review = cfg
+}
+
+// GetReviewConfig returns the active review configuration.
+func (d *Dispatcher) GetReviewConfig() ReviewConfig {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.review
+}
+
+// ParseReviewVerdict extracts the reviewer's verdict from its final response.
+// Reviewers are instructed to finish with "DONE APPROVE: ..." or
+// "DONE REQUEST_CHANGES: ...". The text following the marker is returned as
+// feedback for the author.
+func ParseReviewVerdict(response string) (ReviewVerdict, string) {
+	upper := strings.ToUpper(response)
+	markers := []struct {
+		token   string
+		verdict ReviewVerdict
+	}{
+		{"REQUEST_CHANGES", ReviewVerdictRequestChanges},
+		{"CHANGES_REQUESTED", ReviewVerdictRequestChanges},
+		{"APPROVED", ReviewVerdictApprove},
+		{"APPROVE", ReviewVerdictApprove},
+	}
+	// The last marker in the response wins; earlier mentions are usually the
+	// reviewer restating the instructions.
+	best, bestIdx, bestLen := ReviewVerdictNone, -1, 0
+	for _, m := range markers {
+		if idx := strings.LastIndex(upper, m.token); idx > bestIdx {
+			best, bestIdx, bestLen = m.verdict, idx, len(m.token)
+		}
+	}
+	if bestIdx == -1 {
+		return ReviewVerdictNone, ""
+	}
+	feedback := strings.TrimLeft(response[bestIdx+bestLen:], " :-\t")
+	return best, strings.TrimSpace(feedback)
+}
+
+// isReviewRun reports whether the bead is waiting on the given agent to review it.
+func isReviewRun(b *models.Bead, agentID string) bool {
+	if b == nil || b.Context == nil {
+		return false
+	}
+	return b.Context[reviewStateContextKey] == ReviewStatePending &&
+		b.Context[reviewReviewerContextKey] == agentID
+}
+
+// reviewRound returns the current review round recorded on the bead.
+func reviewRound(b *models.Bead) int {
+	if b == nil || b.Context == nil {
+		return 0
+	}
+	n, _ := strconv.Atoi(b.Context[reviewRoundContextKey])
+	return n
+}
+
+// findReviewer picks an agent with the reviewer role for the bead's project,
+// never the author. Idle agents are preferred.
+func (d *Dispatcher) findReviewer(projectID, authorID, role string) *models.Agent {
+	if d.agents == nil {
+		return nil
+	}
+	roleKey := normalizeRoleName(role)
+	candidates := d.agents.ListAgentsByProject(projectID)
+	if len(candidates) == 0 {
+		candidates = d.agents.ListAgents()
+	}
+	var fallback *models.Agent
+	for _, a := range candidates {
+		if a == nil || a.ID == authorID || normalizeRoleName(a.Role) != roleKey {
+			continue
+		}
+		if a.Status == "idle" {
+			return a
+		}
+		if fallback == nil {
+			fallback = a
+		}
+	}
+	return fallback
+}
+
+// applyReviewTransition inspects a successful task result and, when review is
+// enabled, moves the bead between author and reviewer. It mutates ctxUpdates
+// and updates in place and returns true if the review loop took over routing.
+func (d *Dispatcher) applyReviewTransition(b *models.Bead, agentID, response, terminalReason string, ctxUpdates map[string]string, updates map[string]interface{}) bool {
+	cfg := d.GetReviewConfig()
+	if !cfg.Enabled || b == nil || terminalReason != "completed" {
+		return false
+	}
+	if b.Context != nil && b.Context[reviewStateContextKey] == ReviewStateApproved {
+		return false
+	}
+
+	if isReviewRun(b, agentID) {
+		return d.recordReviewVerdict(b, agentID, response, cfg, ctxUpdates, updates)
+	}
+
+	reviewer := d.findReviewer(b.ProjectID, agentID, cfg.ReviewerRole)
+	if reviewer == nil {
+		log.Printf("[Review] No %s agent available to review bead %s, skipping review", cfg.ReviewerRole, b.ID)
+		return false
+	}
+
+	round := reviewRound(b) + 1
+	ctxUpdates[reviewStateContextKey] = ReviewStatePending
+	ctxUpdates[reviewRoundContextKey] = strconv.Itoa(round)
+	ctxUpdates[reviewAuthorContextKey] = agentID
+	ctxUpdates[reviewReviewerContextKey] = reviewer.ID
+	ctxUpdates["redispatch_requested"] = "true"
+	updates["assigned_to"] = reviewer.ID
+	updates["status"] = models.BeadStatusInProgress
+	log.Printf("[Review] Bead %s round %d: author %s done, assigned reviewer %s", b.ID, round, agentID, reviewer.ID)
+	return true
+}
+
+// recordReviewVerdict handles the reviewer's result for the current round.
+func (d *Dispatcher) recordReviewVerdict(b *models.Bead, reviewerID, response string, cfg ReviewConfig, ctxUpdates map[string]string, updates map[string]interface{}) bool {
+	verdict, feedback := ParseReviewVerdict(response)
+	if verdict == ReviewVerdictNone {
+		// Reviewer finished without a verdict; keep the review pending so it runs again.
+		ctxUpdates["redispatch_requested"] = "true"
+		log.Printf("[Review] Reviewer %s gave no verdict on bead %s, re-requesting review", reviewerID, b.ID)
+		return true
+	}
+
+	round := reviewRound(b)
+	authorID := b.Context[reviewAuthorContextKey]
+	outcomes := appendReviewOutcome(b.Context[reviewOutcomesContextKey], ReviewOutcome{
+		Round:      round,
+		AuthorID:   authorID,
+		ReviewerID: reviewerID,
+		Verdict:    verdict,
+		Feedback:   feedback,
+		ReviewedAt: time.Now().UTC(),
+	})
+	ctxUpdates[reviewOutcomesContextKey] = outcomes
+	ctxUpdates[reviewFeedbackContextKey] = feedback
+
+	switch {
+	case verdict == ReviewVerdictApprove:
+		ctxUpdates[reviewStateContextKey] = ReviewStateApproved
+		ctxUpdates["redispatch_requested"] = "false"
+		updates["assigned_to"] = authorID
+		log.Printf("[Review] Bead %s approved by %s in round %d", b.ID, reviewerID, round)
+	case round >= cfg.MaxRounds:
+		ctxUpdates[reviewStateContextKey] = ReviewStateExhausted
+		ctxUpdates["redispatch_requested"] = "false"
+		updates["status"] = models.BeadStatusBlocked
+		updates["assigned_to"] = authorID
+		log.Printf("[Review] Bead %s exhausted %d review rounds, blocking for human review", b.ID, cfg.MaxRounds)
+	default:
+		ctxUpdates[reviewStateContextKey] = ReviewStateChangesRequested
+		ctxUpdates["redispatch_requested"] = "true"
+		updates["assigned_to"] = authorID
+		updates["status"] = models.BeadStatusInProgress
+		d.reopenAuthorConversation(b, round, feedback)
+		log.Printf("[Review] Bead %s round %d: changes requested by %s, returning to author %s", b.ID, round, reviewerID, authorID)
+	}
+	return true
+}
+
+// reopenAuthorConversation appends the reviewer feedback to the author's
+// conversation session so the next author turn continues where it left off.
+func (d *Dispatcher) reopenAuthorConversation(b *models.Bead, round int, feedback string) {
+	if d.db == nil || b.Context == nil {
+		return
+	}
+	sessionID := b.Context["conversation_session_id"]
+	if sessionID == "" {
+		return
+	}
+	session, err := d.db.GetConversationContext(sessionID)
+	if err != nil || session == nil {
+		return
+	}
+	session.AddMessage("user", fmt.Sprintf("Code review round %d requested changes:\n\n%s\n\nAddress the feedback, commit, and signal DONE again.", round, feedback), 0)
+	if err := d.db.UpdateConversationContext(session); err != nil {
+		log.Printf("[Review] Failed to append review feedback to session %s: %v", sessionID, err)
+	}
+}
+
+func appendReviewOutcome(raw string, outcome ReviewOutcome) string {
+	var outcomes []ReviewOutcome
+	if raw != "" {
+		_ = json.Unmarshal([]byte(raw), &outcomes)
+	}
+	outcomes = append(outcomes, outcome)
+	data, _ := json.Marshal(outcomes)
+	return string(data)
+}
+
+// ReviewOutcomes decodes the review history recorded on a bead.
+func ReviewOutcomes(b *models.Bead) []ReviewOutcome {
+	if b == nil || b.Context == nil || b.Context[reviewOutcomesContextKey] == "" {
+		return nil
+	}
+	var outcomes []ReviewOutcome
+	if err := json.Unmarshal([]byte(b.Context[reviewOutcomesContextKey]), &outcomes); err != nil {
+		return nil
+	}
+	return outcomes
+}
+
+// buildReviewDescription builds the task description handed to a reviewer agent.
+func buildReviewDescription(b *models.Bead) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Review the work done on bead %s: %s (review round %d)\n\n", b.ID, b.Title, reviewRound(b)))
+	sb.WriteString(b.Description)
+	sb.WriteString("\n\n## Author Result\n\n")
+	if b.Context != nil {
+		if first, last := b.Context["agent_first_commit_sha"], b.Context["agent_last_commit_sha"]; first != "" && last != "" {
+			sb.WriteString(fmt.Sprintf("Commits: %s..%s (inspect with git diff %s^ %s)\n\n", first, last, first, last))
+		}
+		output := b.Context["agent_output"]
+		if len(output) > reviewOutputLimit {
+			output = output[len(output)-reviewOutputLimit:]
+		}
+		if output != "" {
+			sb.WriteString(output)
+			sb.WriteString("\n\n")
+		}
+	}
+	sb.WriteString(`## Review Instructions
+
+Inspect the diff and verify it builds and passes tests. Do not modify files.
+Finish with exactly one of:
+  ACTION: DONE APPROVE: <short summary>
+  ACTION: DONE REQUEST_CHANGES: <specific changes the author must make>
+`)
+	return sb.String()
+}
@@ -0,0 +1,199 @@
+package dispatch
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/jordanhubbard/loom/internal/agent"
+	"github.com/jordanhubbard/loom/pkg/models"
+)
+
+func TestParseReviewVerdict(t *testing.T) {
+	tests := []struct {
+		name     string
+		response string
+		verdict  ReviewVerdict
+		feedback string
+	}{
+		{"approve", "ACTION: DONE APPROVE: looks good", ReviewVerdictApprove, "looks good"},
+		{"approved", "Approved - ship it", ReviewVerdictApprove, "ship it"},
+		{"request changes", "ACTION: DONE REQUEST_CHANGES: add tests for nil input", ReviewVerdictRequestChanges, "add tests for nil input"},
+		{"last marker wins", "I could APPROVE but REQUEST_CHANGES: fix the race", ReviewVerdictRequestChanges, "fix the race"},
+		{"none", "ACTION: DONE reviewed", ReviewVerdictNone, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verdict, feedback := ParseReviewVerdict(tt.response)
+			if verdict != tt.verdict {
+				t.Errorf("verdict = %q, want %q", verdict, tt.verdict)
+			}
+			if feedback != tt.feedback {
+				t.Errorf("feedback = %q, want %q", feedback, tt.feedback)
+			}
+		})
+	}
+}
+
+func TestSetReviewConfig_Defaults(t *testing.T) {
+	d := NewDispatcher(nil, nil, nil, nil, nil)
+	d.SetReviewConfig(ReviewConfig{Enabled: true})
+	cfg := d.GetReviewConfig()
+	if cfg.ReviewerRole != defaultReviewerRole {
+		t.Errorf("ReviewerRole = %q, want %q", cfg.ReviewerRole, defaultReviewerRole)
+	}
+	if cfg.MaxRounds != defaultMaxReviewRounds {
+		t.Errorf("MaxRounds = %d, want %d", cfg.MaxRounds, defaultMaxReviewRounds)
+	}
+}
+
+func newReviewTestDispatcher(t *testing.T) (*Dispatcher, *models.Agent, *models.Agent) {
+	t.Helper()
+	wm := agent.NewWorkerManager(10, nil, nil)
+	author, err := wm.CreateAgent(context.Background(), "author", "engineer", "proj-1", "Engineering Manager", &models.Persona{Name: "engineer"})
+	if err != nil {
+		t.Fatalf("create author: %v", err)
+	}
+	reviewer, err := wm.CreateAgent(context.Background(), "reviewer", "code-reviewer", "proj-1", "Code Reviewer", &models.Persona{Name: "code-reviewer"})
+	if err != nil {
+		t.Fatalf("create reviewer: %v", err)
+	}
+	d := NewDispatcher(nil, nil, wm, nil, nil)
+	d.SetReviewConfig(ReviewConfig{Enabled: true, MaxRounds: 2})
+	return d, author, reviewer
+}
+
+func TestApplyReviewTransition_Disabled(t *testing.T) {
+	d := NewDispatcher(nil, nil, nil, nil, nil)
+	b := &models.Bead{ID: "b-1", ProjectID: "proj-1"}
+	ctxUpdates := map[string]string{}
+	updates := map[string]interface{}{}
+	if d.applyReviewTransition(b, "a-1", "done", "completed", ctxUpdates, updates) {
+		t.Fatal("expected no transition when review is disabled")
+	}
+	if len(ctxUpdates) != 0 || len(updates) != 0 {
+		t.Errorf("expected no updates, got %v %v", ctxUpdates, updates)
+	}
+}
+
+func TestApplyReviewTransition_FullLoop(t *testing.T) {
+	d, author, reviewer := newReviewTestDispatcher(t)
+	b := &models.Bead{ID: "b-1", ProjectID: "proj-1", Context: map[string]string{}}
+
+	// Author signals DONE: bead goes to the reviewer.
+	ctxUpdates := map[string]string{}
+	updates := map[string]interface{}{}
+	if !d.applyReviewTransition(b, author.ID, "ACTION: DONE", "completed", ctxUpdates, updates) {
+		t.Fatal("expected review transition after author DONE")
+	}
+	if updates["assigned_to"] != reviewer.ID {
+		t.Fatalf("assigned_to = %v, want reviewer %s", updates["assigned_to"], reviewer.ID)
+	}
+	if ctxUpdates[reviewStateContextKey] != ReviewStatePending || ctxUpdates[reviewRoundContextKey] != "1" {
+		t.Fatalf("unexpected review context: %v", ctxUpdates)
+	}
+	for k, v := range ctxUpdates {
+		b.Context[k] = v
+	}
+	if !isReviewRun(b, reviewer.ID) {
+		t.Fatal("expected bead to be a review run for the reviewer")
+	}
+	if desc := buildReviewDescription(b); !strings.Contains(desc, "REQUEST_CHANGES") {
+		t.Error("expected review description to include verdict instructions")
+	}
+
+	// Reviewer requests changes: bead goes back to the author.
+	ctxUpdates = map[string]string{}
+	updates = map[string]interface{}{}
+	d.applyReviewTransition(b, reviewer.ID, "ACTION: DONE REQUEST_CHANGES: handle errors", "completed", ctxUpdates, updates)
+	if updates["assigned_to"] != author.ID {
+		t.Fatalf("assigned_to = %v, want author %s", updates["assigned_to"], author.ID)
+	}
+	if ctxUpdates[reviewStateContextKey] != ReviewStateChangesRequested {
+		t.Fatalf("review_state = %q, want %q", ctxUpdates[reviewStateContextKey], ReviewStateChangesRequested)
+	}
+	if ctxUpdates[reviewFeedbackContextKey] != "handle errors" {
+		t.Errorf("review_feedback = %q", ctxUpdates[reviewFeedbackContextKey])
+	}
+	for k, v := range ctxUpdates {
+		b.Context[k] = v
+	}
+
+	// Author fixes and signals DONE again: round 2.
+	ctxUpdates = map[string]string{}
+	updates = map[string]interface{}{}
+	d.applyReviewTransition(b, author.ID, "ACTION: DONE", "completed", ctxUpdates, updates)
+	if ctxUpdates[reviewRoundContextKey] != "2" {
+		t.Fatalf("review_round = %q, want 2", ctxUpdates[reviewRoundContextKey])
+	}
+	for k, v := range ctxUpdates {
+		b.Context[k] = v
+	}
+
+	// Reviewer approves.
+	ctxUpdates = map[string]string{}
+	updates = map[string]interface{}{}
+	d.applyReviewTransition(b, reviewer.ID, "ACTION: DONE APPROVE: good now", "completed", ctxUpdates, updates)
+	if ctxUpdates[reviewStateContextKey] != ReviewStateApproved {
+		t.Fatalf("review_state = %q, want approved", ctxUpdates[reviewStateContextKey])
+	}
+	if ctxUpdates["redispatch_requested"] != "false" {
+		t.Error("expected redispatch to stop after approval")
+	}
+	for k, v := range ctxUpdates {
+		b.Context[k] = v
+	}
+
+	outcomes := ReviewOutcomes(b)
+	if len(outcomes) != 2 {
+		t.Fatalf("expected 2 recorded outcomes, got %d", len(outcomes))
+	}
+	if outcomes[0].Verdict != ReviewVerdictRequestChanges || outcomes[1].Verdict != ReviewVerdictApprove {
+		t.Errorf("unexpected outcomes: %+v", outcomes)
+	}
+
+	// Approved beads are not reviewed again.
+	if d.applyReviewTransition(b, author.ID, "ACTION: DONE", "completed", map[string]string{}, map[string]interface{}{}) {
+		t.Error("expected no further review after approval")
+	}
+}
+
+func TestApplyReviewTransition_MaxRounds(t *testing.T) {
+	d, author, reviewer := newReviewTestDispatcher(t)
+	b := &models.Bead{ID: "b-2", ProjectID: "proj-1", Context: map[string]string{
+		reviewStateContextKey:    ReviewStatePending,
+		reviewRoundContextKey:    "2",
+		reviewAuthorContextKey:   author.ID,
+		reviewReviewerContextKey: reviewer.ID,
+	}}
+	ctxUpdates := map[string]string{}
+	updates := map[string]interface{}{}
+	d.applyReviewTransition(b, reviewer.ID, "REQUEST_CHANGES: still broken", "completed", ctxUpdates, updates)
+	if ctxUpdates[reviewStateContextKey] != ReviewStateExhausted {
+		t.Fatalf("review_state = %q, want exhausted", ctxUpdates[reviewStateContextKey])
+	}
+	if updates["status"] != models.BeadStatusBlocked {
+		t.Errorf("status = %v, want blocked", updates["status"])
+	}
+}
+
+func TestApplyReviewTransition_NoVerdictKeepsPending(t *testing.T) {
+	d, author, reviewer := newReviewTestDispatcher(t)
+	b := &models.Bead{ID: "b-3", ProjectID: "proj-1", Context: map[string]string{
+		reviewStateContextKey:    ReviewStatePending,
+		reviewRoundContextKey:    "1",
+		reviewAuthorContextKey:   author.ID,
+		reviewReviewerContextKey: reviewer.ID,
+	}}
+	ctxUpdates := map[string]string{}
+	updates := map[string]interface{}{}
+	if !d.applyReviewTransition(b, reviewer.ID, "ACTION: DONE", "completed", ctxUpdates, updates) {
+		t.Fatal("expected review loop to handle reviewer result")
+	}
+	if _, ok := ctxUpdates[reviewStateContextKey]; ok {
+		t.Error("expected review_state to stay pending")
+	}
+	if ctxUpdates["redispatch_requested"] != "true" {
+		t.Error("expected redispatch for another review attempt")
+	}
+}
@@ -37,6 +37,7 @@ import (
 	"github.com/jordanhubbard/loom/internal/openclaw"
 	"github.com/jordanhubbard/loom/internal/orgchart"
 	"github.com/jordanhubbard/loom/internal/patterns"
+	"github.com/jordanhubbard/loom/internal/pricing"
 	"github.com/jordanhubbard/loom/internal/persona"
 	"github.com/jordanhubbard/loom/internal/project"
 	"github.com/jordanhubbard/loom/internal/provider"
@@ -86,6 +87,7 @@ type Loom struct {
 	idleDetector        *motivation.IdleDetector
 	workflowEngine      *workflow.Engine
 	patternManager      *patterns.Manager
+	pricingCatalog      *pricing.Catalog
 	metrics             *metrics.Metrics
 	keyManager          *keymanager.KeyManager
 	doltCoordinator     *beads.DoltCoordinator
@@ -245,6 +247,21 @@ func New(cfg *config.Config) (*Loom, error) {
 		commentsMgr = comments.NewManager(db, notificationMgr, eb)
 	}
 
+	// Initialize the pricing catalog; the optimizer and analytics cost
+	// estimates read from the process-wide default.
+	pricingCatalog := pricing.DefaultCatalog()
+	if cfg.Pricing.CatalogPath != "" {
+		if err := pricingCatalog.LoadFile(cfg.Pricing.CatalogPath); err != nil {
+			log.Printf("[Pricing] Warning: using built-in prices: %v", err)
+		}
+	}
+	if db != nil {
+		if err := pricingCatalog.SetStore(db); err != nil {
+			log.Printf("[Pricing] Warning: failed to load price overrides: %v", err)
+		}
+	}
+	pricing.SetDefault(pricingCatalog)
+
 	// Initialize pattern manager and analytics logger if database is available
 	var patternMgr *patterns.Manager
 	if db != nil {
@@ -252,7 +269,9 @@ func New(cfg *config.Config) (*Loom, error) {
 		if err == nil && analyticsStorage != nil {
 			patternMgr = patterns.NewManager(analyticsStorage, nil)
 			// Wire analytics logger to WorkerManager so LLM completions are logged
-			agentMgr.SetAnalyticsLogger(analytics.NewLogger(analyticsStorage, analytics.DefaultPrivacyConfig()))
+			analyticsLogger := analytics.NewLogger(analyticsStorage, analytics.DefaultPrivacyConfig())
+			analyticsLogger.SetCostEstimator(pricingCatalog)
+			agentMgr.SetAnalyticsLogger(analyticsLogger)
 		}
 	}
 
@@ -294,6 +313,7 @@ func New(cfg *config.Config) (*Loom, error) {
 		idleDetector:        idleDetector,
 		workflowEngine:      workflowEngine,
 		patternManager:      patternMgr,
+		pricingCatalog:      pricingCatalog,
 		metrics:             metrics.NewMetrics(),
 		doltCoordinator:     doltCoord,
 		openclawClient:      ocClient,
@@ -332,6 +352,11 @@ func New(cfg *config.Config) (*Loom, error) {
 	arb.dispatcher.SetReadinessCheck(arb.CheckProjectReadiness)
 	arb.dispatcher.SetReadinessMode(dispatch.ReadinessMode(cfg.Readiness.Mode))
 	arb.dispatcher.SetMaxDispatchHops(cfg.Dispatch.MaxHops)
+	arb.dispatcher.SetReviewConfig(dispatch.ReviewConfig{
+		Enabled:      cfg.Dispatch.Review.Enabled,
+		ReviewerRole: cfg.Dispatch.Review.ReviewerRole,
+		MaxRounds:    cfg.Dispatch.Review.MaxRounds,
+	})
 	arb.dispatcher.SetEscalator(arb)
 	// Enable conversation context support for multi-turn conversations
 	if db != nil {
@@ -892,6 +917,11 @@ func (a *Loom) Initialize(ctx context.Context) error {
 		}
 	}
 
+	// Pick up edits to the pricing catalog file without a restart
+	if a.pricingCatalog != nil {
+		a.pricingCatalog.StartRefresh(ctx, a.config.Pricing.RefreshInterval)
+	}
+
 	// FIX #1: Start motivation engine evaluation loop
 	// The motivation engine creates beads automatically based on conditions
 	// (idle detection, deadline monitoring, budget thresholds, etc.)
@@ -1217,6 +1247,11 @@ func (a *Loom) GetLogManager() *logging.Manager {
 	return a.logManager
 }
 
+// GetPricingCatalog returns the model pricing catalog
+func (a *Loom) GetPricingCatalog() *pricing.Catalog {
+	return a.pricingCatalog
+}
+
 // GetPatternManager returns the pattern manager
 func (a *Loom) GetPatternManager() *patterns.Manager {
 	return a.patternManager
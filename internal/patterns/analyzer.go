@@ -80,6 +80,27 @@ func (a *Analyzer) AnalyzePatterns(ctx context.Context, config *AnalysisConfig)
 		clusterSummaries["latency"] = a.summarizeCluster(latencyPatterns)
 	}
 
+	var hourly, daily []int64
+	if config.EnableTemporalPatterns {
+		hourly, daily = buildHistograms(logs)
+
+		hourlyPatterns := a.clusterByHourOfDay(logs, config)
+		allPatterns = append(allPatterns, hourlyPatterns...)
+		clusterSummaries["hourly"] = a.summarizeCluster(hourlyPatterns)
+
+		dailyPatterns := a.clusterByDayOfWeek(logs, config)
+		allPatterns = append(allPatterns, dailyPatterns...)
+		clusterSummaries["daily"] = a.summarizeCluster(dailyPatterns)
+
+		burstPatterns := a.detectBursts(logs, config)
+		allPatterns = append(allPatterns, burstPatterns...)
+		clusterSummaries["burst"] = a.summarizeCluster(burstPatterns)
+
+		repeatedPatterns := a.detectRepeatedPrompts(logs, config)
+		allPatterns = append(allPatterns, repeatedPatterns...)
+		clusterSummaries["repeated-prompt"] = a.summarizeCluster(repeatedPatterns)
+	}
+
 	// Sort all patterns by total cost descending
 	sort.Slice(allPatterns, func(i, j int) bool {
 		return allPatterns[i].TotalCost > allPatterns[j].TotalCost
@@ -93,6 +114,9 @@ func (a *Analyzer) AnalyzePatterns(ctx context.Context, config *AnalysisConfig)
 
 	// Generate recommendations based on expensive patterns
 	recommendations := a.generateRecommendations(expensivePatterns)
+	if config.EnableTemporalPatterns {
+		recommendations = append(recommendations, a.generateTemporalRecommendations(allPatterns, hourly)...)
+	}
 
 	return &PatternReport{
 		AnalyzedAt:       time.Now(),
@@ -103,6 +127,8 @@ func (a *Analyzer) AnalyzePatterns(ctx context.Context, config *AnalysisConfig)
 		Anomalies:        anomalies,
 		ClusterSummaries: clusterSummaries,
 		Recommendations:  recommendations,
+		HourlyHistogram:  hourly,
+		DailyHistogram:   daily,
 	}, nil
 }
 
@@ -574,6 +600,45 @@ func (a *Analyzer) generateRecommendations(patterns []*UsagePattern) []string {
 	return recommendations
 }
 
+// generateTemporalRecommendations suggests off-peak scheduling and prompt
+// caching based on hourly peaks, bursts, and repeated prompts.
+func (a *Analyzer) generateTemporalRecommendations(patterns []*UsagePattern, hourly []int64) []string {
+	var recommendations []string
+	var peak, repeated *UsagePattern
+	bursts := 0
+	for _, p := range patterns {
+		switch p.Type {
+		case "hourly":
+			if peak == nil || p.TrafficShare > peak.TrafficShare {
+				peak = p
+			}
+		case "burst":
+			bursts++
+		case "repeated-prompt":
+			if repeated == nil || p.TotalCost > repeated.TotalCost {
+				repeated = p
+			}
+		}
+	}
+
+	if peak != nil {
+		quiet := offPeakHours(hourly, 4)
+		recommendations = append(recommendations,
+			fmt.Sprintf("%s carries %.0f%% of traffic - schedule batch work off-peak (quietest hours UTC: %v)",
+				peak.GroupKey, peak.TrafficShare*100, quiet))
+	}
+	if bursts > 0 {
+		recommendations = append(recommendations,
+			fmt.Sprintf("%d request bursts detected - consider queuing or smoothing bursty workloads", bursts))
+	}
+	if repeated != nil {
+		recommendations = append(recommendations,
+			fmt.Sprintf("Identical prompt repeated %d times ($%.2f) - enable response caching for repeated prompts",
+				repeated.RepeatedCount, repeated.TotalCost))
+	}
+	return recommendations
+}
+
 // summarizeCluster creates a summary of a cluster
 func (a *Analyzer) summarizeCluster(patterns []*UsagePattern) *ClusterSummary {
 	if len(patterns) == 0 {
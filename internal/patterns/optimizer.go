@@ -35,6 +35,17 @@ func (o *Optimizer) GenerateRecommendations(patterns []*UsagePattern) []*Optimiz
 			}
 		}
 
+		switch pattern.Type {
+		case "hourly":
+			if opt := o.createOffPeakOptimization(pattern); opt != nil {
+				optimizations = append(optimizations, opt)
+			}
+		case "repeated-prompt":
+			if opt := o.createCachingOptimization(pattern); opt != nil {
+				optimizations = append(optimizations, opt)
+			}
+		}
+
 		// Provider substitution recommendations (enhanced version)
 		if o.config.EnableSubstitutions && pattern.Type == "provider-model" {
 			opt := o.createEnhancedSubstitutionOptimization(pattern)
@@ -79,6 +90,56 @@ func (o *Optimizer) createRateLimitOptimization(pattern *UsagePattern) *Optimiza
 	}
 }
 
+// createOffPeakOptimization recommends moving deferrable work out of a peak hour.
+// Savings are not projected: off-peak scheduling reduces contention and
+// latency rather than list price.
+func (o *Optimizer) createOffPeakOptimization(pattern *UsagePattern) *Optimization {
+	if pattern.TrafficShare < o.config.PeakShareThreshold {
+		return nil
+	}
+	return &Optimization{
+		ID:             uuid.New().String(),
+		Type:           "off-peak-scheduling",
+		Pattern:        pattern,
+		Recommendation: fmt.Sprintf("Schedule batch work off-peak: %s carries %.0f%% of requests", pattern.GroupKey, pattern.TrafficShare*100),
+		CurrentCost:    pattern.TotalCost,
+		ProjectedCost:  pattern.TotalCost,
+		ImpactRating:   "low",
+		QualityImpact:  "none",
+		AutoApplicable: false,
+		Confidence:     0.6,
+	}
+}
+
+// createCachingOptimization recommends caching for a repeated identical prompt.
+// Every repeat after the first is assumed to be served from cache.
+func (o *Optimizer) createCachingOptimization(pattern *UsagePattern) *Optimization {
+	if pattern.RequestCount < 2 || pattern.AvgCost <= 0 {
+		return nil
+	}
+	savings := pattern.AvgCost * float64(pattern.RequestCount-1)
+	windowDays := o.config.TimeWindow.Hours() / 24
+	if windowDays < 1 {
+		windowDays = 1
+	}
+	monthlySavings := savings * 30 / windowDays
+
+	return &Optimization{
+		ID:                  uuid.New().String(),
+		Type:                "caching",
+		Pattern:             pattern,
+		Recommendation:      fmt.Sprintf("Enable caching for repeated prompt %s (%d identical requests)", pattern.GroupKey, pattern.RequestCount),
+		CurrentCost:         pattern.TotalCost,
+		ProjectedCost:       pattern.TotalCost - savings,
+		ProjectedSavingsUSD: savings,
+		MonthlySavingsUSD:   monthlySavings,
+		ImpactRating:        getImpactRating(monthlySavings),
+		QualityImpact:       "none",
+		AutoApplicable:      true,
+		Confidence:          0.9,
+	}
+}
+
 // Helper functions
 
 func getImpactRating(monthlySavings float64) string {
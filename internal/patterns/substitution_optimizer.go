@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/google/uuid"
+	"github.com/jordanhubbard/loom/internal/pricing"
 )
 
 // ProviderCostEstimate contains cost estimates for different providers/models
@@ -16,42 +17,31 @@ type ProviderCostEstimate struct {
 	Tier               string  // "budget", "mid-tier", "premium"
 }
 
-// GetProviderCostEstimates returns estimated costs for common providers
-// In a production system, this would query a pricing database
+// GetProviderCostEstimates returns the effective prices from the pricing catalog
 func GetProviderCostEstimates() []ProviderCostEstimate {
-	return []ProviderCostEstimate{
-		// OpenAI Models
-		{ProviderID: "openai", ModelName: "gpt-4", CostPer1KTokensUSD: 0.03, QualityScore: 0.95, Tier: "premium"},
-		{ProviderID: "openai", ModelName: "gpt-4-turbo", CostPer1KTokensUSD: 0.01, QualityScore: 0.93, Tier: "premium"},
-		{ProviderID: "openai", ModelName: "gpt-3.5-turbo", CostPer1KTokensUSD: 0.002, QualityScore: 0.80, Tier: "mid-tier"},
-
-		// Anthropic Models
-		{ProviderID: "anthropic", ModelName: "claude-opus-4-5", CostPer1KTokensUSD: 0.015, QualityScore: 0.96, Tier: "premium"},
-		{ProviderID: "anthropic", ModelName: "claude-sonnet-4-5", CostPer1KTokensUSD: 0.003, QualityScore: 0.90, Tier: "premium"},
-		{ProviderID: "anthropic", ModelName: "claude-haiku-3-5", CostPer1KTokensUSD: 0.0008, QualityScore: 0.75, Tier: "budget"},
-
-		// Other providers (local/self-hosted typically cheaper)
-		{ProviderID: "local", ModelName: "llama-3", CostPer1KTokensUSD: 0.0, QualityScore: 0.70, Tier: "budget"},
-		{ProviderID: "ollama", ModelName: "mistral", CostPer1KTokensUSD: 0.0, QualityScore: 0.68, Tier: "budget"},
+	prices := pricing.Default().List()
+	estimates := make([]ProviderCostEstimate, 0, len(prices))
+	for _, p := range prices {
+		estimates = append(estimates, estimateFromPrice(p))
 	}
+	return estimates
 }
 
-// FindProviderCostEstimate looks up cost estimate for a provider/model
-func FindProviderCostEstimate(providerID, modelName string) *ProviderCostEstimate {
-	estimates := GetProviderCostEstimates()
-
-	// Try exact match first
-	for _, est := range estimates {
-		if strings.EqualFold(est.ProviderID, providerID) && strings.EqualFold(est.ModelName, modelName) {
-			return &est
-		}
+func estimateFromPrice(p pricing.Price) ProviderCostEstimate {
+	return ProviderCostEstimate{
+		ProviderID:         p.ProviderID,
+		ModelName:          p.ModelName,
+		CostPer1KTokensUSD: p.CostPer1KTokensUSD,
+		QualityScore:       p.QualityScore,
+		Tier:               p.Tier,
 	}
+}
 
-	// Try fuzzy match on model name
-	for _, est := range estimates {
-		if strings.EqualFold(est.ProviderID, providerID) && strings.Contains(strings.ToLower(modelName), strings.ToLower(est.ModelName)) {
-			return &est
-		}
+// FindProviderCostEstimate looks up cost estimate for a provider/model
+func FindProviderCostEstimate(providerID, modelName string) *ProviderCostEstimate {
+	if p, ok := pricing.Default().Lookup(providerID, modelName); ok {
+		est := estimateFromPrice(p)
+		return &est
 	}
 
 	// Return default estimate
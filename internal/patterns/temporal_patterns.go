@@ -0,0 +1,278 @@
+package patterns
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jordanhubbard/loom/internal/analytics"
+)
+
+// buildHistograms counts requests per UTC hour of day and per day of week.
+func buildHistograms(logs []*analytics.RequestLog) (hourly []int64, daily []int64) {
+	hourly = make([]int64, 24)
+	daily = make([]int64, 7)
+	for _, log := range logs {
+		ts := log.Timestamp.UTC()
+		hourly[ts.Hour()]++
+		daily[int(ts.Weekday())]++
+	}
+	return hourly, daily
+}
+
+// clusterByHourOfDay produces one "hourly" pattern per UTC hour that carries
+// at least PeakShareThreshold of all traffic.
+func (a *Analyzer) clusterByHourOfDay(logs []*analytics.RequestLog, config *AnalysisConfig) []*UsagePattern {
+	if len(logs) == 0 {
+		return nil
+	}
+	buckets := make(map[int]*UsagePattern)
+	for _, log := range logs {
+		hour := log.Timestamp.UTC().Hour()
+		pattern, ok := buckets[hour]
+		if !ok {
+			h := hour
+			pattern = &UsagePattern{
+				ID:        uuid.New().String(),
+				Type:      "hourly",
+				GroupKey:  fmt.Sprintf("%02d:00 UTC", hour),
+				HourOfDay: &h,
+				FirstSeen: log.Timestamp,
+				LastSeen:  log.Timestamp,
+			}
+			buckets[hour] = pattern
+		}
+		addToPattern(pattern, log)
+	}
+
+	total := float64(len(logs))
+	patterns := make([]*UsagePattern, 0, len(buckets))
+	for _, pattern := range buckets {
+		finalizePattern(pattern)
+		pattern.TrafficShare = float64(pattern.RequestCount) / total
+		if pattern.TrafficShare >= config.PeakShareThreshold && pattern.RequestCount >= int64(config.MinRequests) {
+			patterns = append(patterns, pattern)
+		}
+	}
+	sort.Slice(patterns, func(i, j int) bool {
+		return patterns[i].RequestCount > patterns[j].RequestCount
+	})
+	return patterns
+}
+
+// clusterByDayOfWeek produces one "daily" pattern per weekday with traffic.
+func (a *Analyzer) clusterByDayOfWeek(logs []*analytics.RequestLog, config *AnalysisConfig) []*UsagePattern {
+	if len(logs) == 0 {
+		return nil
+	}
+	buckets := make(map[time.Weekday]*UsagePattern)
+	for _, log := range logs {
+		day := log.Timestamp.UTC().Weekday()
+		pattern, ok := buckets[day]
+		if !ok {
+			pattern = &UsagePattern{
+				ID:        uuid.New().String(),
+				Type:      "daily",
+				GroupKey:  day.String(),
+				DayOfWeek: day.String(),
+				FirstSeen: log.Timestamp,
+				LastSeen:  log.Timestamp,
+			}
+			buckets[day] = pattern
+		}
+		addToPattern(pattern, log)
+	}
+
+	total := float64(len(logs))
+	patterns := make([]*UsagePattern, 0, len(buckets))
+	for _, pattern := range buckets {
+		finalizePattern(pattern)
+		pattern.TrafficShare = float64(pattern.RequestCount) / total
+		if pattern.RequestCount >= int64(config.MinRequests) {
+			patterns = append(patterns, pattern)
+		}
+	}
+	return patterns
+}
+
+// detectBursts buckets requests into BurstWindow slots and reports runs of
+// consecutive slots whose rate exceeds BurstMultiplier times the mean rate
+// over active slots.
+func (a *Analyzer) detectBursts(logs []*analytics.RequestLog, config *AnalysisConfig) []*UsagePattern {
+	window := config.BurstWindow
+	if len(logs) == 0 || window <= 0 || config.BurstMultiplier <= 0 {
+		return nil
+	}
+
+	slots := make(map[int64][]*analytics.RequestLog)
+	for _, log := range logs {
+		slot := log.Timestamp.UnixNano() / int64(window)
+		slots[slot] = append(slots[slot], log)
+	}
+	if len(slots) < 2 {
+		return nil
+	}
+
+	keys := make([]int64, 0, len(slots))
+	for k := range slots {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+
+	mean := float64(len(logs)) / float64(len(slots))
+	threshold := mean * config.BurstMultiplier
+	if threshold < float64(config.MinBurstRequests) {
+		threshold = float64(config.MinBurstRequests)
+	}
+
+	var patterns []*UsagePattern
+	var current *UsagePattern
+	var lastSlot int64
+	for _, slot := range keys {
+		entries := slots[slot]
+		if float64(len(entries)) < threshold {
+			current = nil
+			continue
+		}
+		if current == nil || slot != lastSlot+1 {
+			start := time.Unix(0, slot*int64(window)).UTC()
+			current = &UsagePattern{
+				ID:         uuid.New().String(),
+				Type:       "burst",
+				GroupKey:   fmt.Sprintf("burst@%s", start.Format(time.RFC3339)),
+				BurstStart: start,
+				FirstSeen:  entries[0].Timestamp,
+				LastSeen:   entries[0].Timestamp,
+			}
+			patterns = append(patterns, current)
+		}
+		current.BurstEnd = time.Unix(0, (slot+1)*int64(window)).UTC()
+		if rate := float64(len(entries)); rate > current.PeakRate {
+			current.PeakRate = rate
+		}
+		for _, log := range entries {
+			addToPattern(current, log)
+		}
+		lastSlot = slot
+	}
+
+	for _, pattern := range patterns {
+		finalizePattern(pattern)
+		pattern.TrafficShare = float64(pattern.RequestCount) / float64(len(logs))
+	}
+	return patterns
+}
+
+// detectRepeatedPrompts groups requests with identical prompts. The prompt is
+// identified by the logged request body, or by a "prompt_hash" metadata entry
+// when bodies are redacted by the privacy config.
+func (a *Analyzer) detectRepeatedPrompts(logs []*analytics.RequestLog, config *AnalysisConfig) []*UsagePattern {
+	minRepeats := config.MinRepeatedPrompts
+	if minRepeats < 2 {
+		minRepeats = 2
+	}
+
+	groups := make(map[string]*UsagePattern)
+	for _, log := range logs {
+		hash := promptHash(log)
+		if hash == "" {
+			continue
+		}
+		pattern, ok := groups[hash]
+		if !ok {
+			pattern = &UsagePattern{
+				ID:         uuid.New().String(),
+				Type:       "repeated-prompt",
+				GroupKey:   "prompt:" + hash[:12],
+				PromptHash: hash,
+				ProviderID: log.ProviderID,
+				ModelName:  log.ModelName,
+				FirstSeen:  log.Timestamp,
+				LastSeen:   log.Timestamp,
+			}
+			groups[hash] = pattern
+		}
+		addToPattern(pattern, log)
+	}
+
+	var patterns []*UsagePattern
+	for _, pattern := range groups {
+		if pattern.RequestCount < int64(minRepeats) {
+			continue
+		}
+		finalizePattern(pattern)
+		pattern.RepeatedCount = pattern.RequestCount
+		patterns = append(patterns, pattern)
+	}
+	sort.Slice(patterns, func(i, j int) bool {
+		return patterns[i].TotalCost > patterns[j].TotalCost
+	})
+	return patterns
+}
+
+func promptHash(log *analytics.RequestLog) string {
+	if log.Metadata != nil && log.Metadata["prompt_hash"] != "" {
+		return log.Metadata["prompt_hash"]
+	}
+	if log.RequestBody == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(log.RequestBody))
+	return hex.EncodeToString(sum[:])
+}
+
+// addToPattern folds a single log into a pattern's running totals.
+func addToPattern(pattern *UsagePattern, log *analytics.RequestLog) {
+	pattern.RequestCount++
+	pattern.TotalCost += log.CostUSD
+	pattern.TotalTokens += log.TotalTokens
+	pattern.AvgLatency += (float64(log.LatencyMs) - pattern.AvgLatency) / float64(pattern.RequestCount)
+	errVal := 0.0
+	if log.ErrorMessage != "" {
+		errVal = 1.0
+	}
+	pattern.ErrorRate += (errVal - pattern.ErrorRate) / float64(pattern.RequestCount)
+	if log.Timestamp.After(pattern.LastSeen) {
+		pattern.LastSeen = log.Timestamp
+	}
+	if log.Timestamp.Before(pattern.FirstSeen) {
+		pattern.FirstSeen = log.Timestamp
+	}
+}
+
+// finalizePattern computes derived averages and request frequency.
+func finalizePattern(pattern *UsagePattern) {
+	if pattern.RequestCount == 0 {
+		return
+	}
+	pattern.AvgCost = pattern.TotalCost / float64(pattern.RequestCount)
+	pattern.AvgTokens = pattern.TotalTokens / pattern.RequestCount
+	daysSpan := pattern.LastSeen.Sub(pattern.FirstSeen).Hours() / 24
+	if daysSpan < 1 {
+		daysSpan = 1
+	}
+	pattern.RequestFrequency = float64(pattern.RequestCount) / daysSpan
+}
+
+// offPeakHours returns the quietest hours of the day from a 24-bucket histogram.
+func offPeakHours(hourly []int64, n int) []int {
+	if len(hourly) != 24 {
+		return nil
+	}
+	hours := make([]int, 24)
+	for i := range hours {
+		hours[i] = i
+	}
+	sort.SliceStable(hours, func(i, j int) bool {
+		return hourly[hours[i]] < hourly[hours[j]]
+	})
+	if n > len(hours) {
+		n = len(hours)
+	}
+	quiet := hours[:n]
+	sort.Ints(quiet)
+	return quiet
+}
@@ -0,0 +1,169 @@
+package patterns
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jordanhubbard/loom/internal/analytics"
+)
+
+func temporalTestConfig() *AnalysisConfig {
+	cfg := DefaultAnalysisConfig()
+	cfg.MinRequests = 1
+	cfg.MinCostUSD = 0
+	cfg.MinBurstRequests = 5
+	cfg.MinRepeatedPrompts = 3
+	return cfg
+}
+
+func TestBuildHistograms(t *testing.T) {
+	base := time.Date(2026, 1, 4, 9, 30, 0, 0, time.UTC) // Sunday
+	logs := []*analytics.RequestLog{
+		{Timestamp: base},
+		{Timestamp: base.Add(10 * time.Minute)},
+		{Timestamp: base.Add(25 * time.Hour)}, // Monday 10:30
+	}
+	hourly, daily := buildHistograms(logs)
+	if hourly[9] != 2 || hourly[10] != 1 {
+		t.Errorf("unexpected hourly histogram: %v", hourly)
+	}
+	if daily[0] != 2 || daily[1] != 1 {
+		t.Errorf("unexpected daily histogram: %v", daily)
+	}
+}
+
+func TestClusterByHourOfDay_FlagsPeak(t *testing.T) {
+	a := NewAnalyzer(&MockStorage{}, nil)
+	base := time.Date(2026, 1, 5, 14, 0, 0, 0, time.UTC)
+	var logs []*analytics.RequestLog
+	for i := 0; i < 8; i++ {
+		logs = append(logs, &analytics.RequestLog{Timestamp: base.Add(time.Duration(i) * time.Minute), CostUSD: 0.01})
+	}
+	for h := 0; h < 4; h++ {
+		logs = append(logs, &analytics.RequestLog{Timestamp: base.Add(time.Duration(h+1) * time.Hour), CostUSD: 0.01})
+	}
+
+	cfg := temporalTestConfig()
+	cfg.PeakShareThreshold = 0.5
+	patterns := a.clusterByHourOfDay(logs, cfg)
+	if len(patterns) != 1 {
+		t.Fatalf("expected 1 peak hour, got %d", len(patterns))
+	}
+	if *patterns[0].HourOfDay != 14 {
+		t.Errorf("peak hour = %d, want 14", *patterns[0].HourOfDay)
+	}
+	if patterns[0].TrafficShare < 0.6 {
+		t.Errorf("traffic share = %.2f, want >= 0.6", patterns[0].TrafficShare)
+	}
+}
+
+func TestDetectBursts(t *testing.T) {
+	a := NewAnalyzer(&MockStorage{}, nil)
+	base := time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)
+	var logs []*analytics.RequestLog
+	// One request per minute for an hour...
+	for i := 0; i < 60; i++ {
+		logs = append(logs, &analytics.RequestLog{Timestamp: base.Add(time.Duration(i) * time.Minute)})
+	}
+	// ...plus a 2-minute burst of 40 requests each.
+	for i := 0; i < 80; i++ {
+		logs = append(logs, &analytics.RequestLog{Timestamp: base.Add(30*time.Minute + time.Duration(i)*1500*time.Millisecond)})
+	}
+
+	cfg := temporalTestConfig()
+	cfg.BurstMultiplier = 3
+	bursts := a.detectBursts(logs, cfg)
+	if len(bursts) != 1 {
+		t.Fatalf("expected 1 burst, got %d", len(bursts))
+	}
+	b := bursts[0]
+	if !b.BurstStart.Equal(base.Add(30 * time.Minute)) {
+		t.Errorf("burst start = %v", b.BurstStart)
+	}
+	if b.BurstEnd.Sub(b.BurstStart) != 2*time.Minute {
+		t.Errorf("burst duration = %v, want 2m", b.BurstEnd.Sub(b.BurstStart))
+	}
+	if b.PeakRate < 40 {
+		t.Errorf("peak rate = %.0f, want >= 40", b.PeakRate)
+	}
+}
+
+func TestDetectBursts_NoBurstInSteadyTraffic(t *testing.T) {
+	a := NewAnalyzer(&MockStorage{}, nil)
+	base := time.Now().Add(-time.Hour)
+	var logs []*analytics.RequestLog
+	for i := 0; i < 60; i++ {
+		logs = append(logs, &analytics.RequestLog{Timestamp: base.Add(time.Duration(i) * time.Minute)})
+	}
+	if bursts := a.detectBursts(logs, temporalTestConfig()); len(bursts) != 0 {
+		t.Errorf("expected no bursts, got %d", len(bursts))
+	}
+}
+
+func TestDetectRepeatedPrompts(t *testing.T) {
+	a := NewAnalyzer(&MockStorage{}, nil)
+	now := time.Now()
+	var logs []*analytics.RequestLog
+	for i := 0; i < 4; i++ {
+		logs = append(logs, &analytics.RequestLog{Timestamp: now, RequestBody: "summarize the README", CostUSD: 0.05})
+	}
+	logs = append(logs,
+		&analytics.RequestLog{Timestamp: now, RequestBody: "unique prompt", CostUSD: 0.05},
+		&analytics.RequestLog{Timestamp: now, Metadata: map[string]string{"prompt_hash": "abcdef0123456789"}, CostUSD: 0.01},
+	)
+
+	patterns := a.detectRepeatedPrompts(logs, temporalTestConfig())
+	if len(patterns) != 1 {
+		t.Fatalf("expected 1 repeated prompt group, got %d", len(patterns))
+	}
+	if patterns[0].RepeatedCount != 4 {
+		t.Errorf("repeated count = %d, want 4", patterns[0].RepeatedCount)
+	}
+
+	opt := NewOptimizer(temporalTestConfig()).createCachingOptimization(patterns[0])
+	if opt == nil {
+		t.Fatal("expected caching optimization")
+	}
+	if opt.ProjectedSavingsUSD < 0.149 || opt.ProjectedSavingsUSD > 0.151 {
+		t.Errorf("projected savings = %.4f, want 0.15", opt.ProjectedSavingsUSD)
+	}
+}
+
+func TestAnalyzePatterns_IncludesTemporalPatterns(t *testing.T) {
+	base := time.Now().Add(-2 * time.Hour).Truncate(time.Hour)
+	var logs []*analytics.RequestLog
+	for i := 0; i < 10; i++ {
+		logs = append(logs, &analytics.RequestLog{
+			Timestamp:   base.Add(time.Duration(i) * time.Minute),
+			RequestBody: "same prompt",
+			CostUSD:     0.02,
+		})
+	}
+	a := NewAnalyzer(&MockStorage{logs: logs}, nil)
+	report, err := a.AnalyzePatterns(context.Background(), temporalTestConfig())
+	if err != nil {
+		t.Fatalf("AnalyzePatterns: %v", err)
+	}
+	if len(report.HourlyHistogram) != 24 || len(report.DailyHistogram) != 7 {
+		t.Fatalf("expected histograms, got %d/%d buckets", len(report.HourlyHistogram), len(report.DailyHistogram))
+	}
+	types := map[string]bool{}
+	for _, p := range report.Patterns {
+		types[p.Type] = true
+	}
+	for _, want := range []string{"hourly", "daily", "repeated-prompt"} {
+		if !types[want] {
+			t.Errorf("expected %s pattern in report", want)
+		}
+	}
+
+	opts := NewOptimizer(temporalTestConfig()).GenerateRecommendations(report.Patterns)
+	optTypes := map[string]bool{}
+	for _, o := range opts {
+		optTypes[o.Type] = true
+	}
+	if !optTypes["caching"] || !optTypes["off-peak-scheduling"] {
+		t.Errorf("expected caching and off-peak optimizations, got %v", optTypes)
+	}
+}
@@ -5,7 +5,7 @@ import "time"
 // UsagePattern represents a detected pattern in API usage
 type UsagePattern struct {
 	ID               string    `json:"id"`
-	Type             string    `json:"type"` // "provider-model", "user", "cost-band", "temporal", "latency"
+	Type             string    `json:"type"` // "provider-model", "user", "cost-band", "temporal", "latency", "hourly", "daily", "burst", "repeated-prompt"
 	GroupKey         string    `json:"group_key"`
 	RequestCount     int64     `json:"request_count"`
 	TotalCost        float64   `json:"total_cost"`
@@ -28,6 +28,16 @@ type UsagePattern struct {
 	UsesFunction     bool   `json:"uses_function,omitempty"`
 	UsesVision       bool   `json:"uses_vision,omitempty"`
 	AvgTokens        int64  `json:"avg_tokens,omitempty"`
+
+	// Temporal pattern context
+	HourOfDay     *int      `json:"hour_of_day,omitempty"`
+	DayOfWeek     string    `json:"day_of_week,omitempty"`
+	TrafficShare  float64   `json:"traffic_share,omitempty"` // fraction of all requests in this bucket
+	BurstStart    time.Time `json:"burst_start,omitempty"`
+	BurstEnd      time.Time `json:"burst_end,omitempty"`
+	PeakRate      float64   `json:"peak_rate,omitempty"` // requests per burst window at the peak
+	PromptHash    string    `json:"prompt_hash,omitempty"`
+	RepeatedCount int64     `json:"repeated_count,omitempty"`
 }
 
 // ClusterSummary summarizes a cluster of patterns
@@ -62,6 +72,8 @@ type PatternReport struct {
 	Anomalies        []*PatternAnomaly          `json:"anomalies"`
 	ClusterSummaries map[string]*ClusterSummary `json:"cluster_summaries"`
 	Recommendations  []string                   `json:"recommendations"`
+	HourlyHistogram  []int64                    `json:"hourly_histogram,omitempty"` // 24 buckets, UTC hour
+	DailyHistogram   []int64                    `json:"daily_histogram,omitempty"`  // 7 buckets, Sunday first
 }
 
 // AnalysisConfig configures pattern analysis behavior
@@ -75,6 +87,14 @@ type AnalysisConfig struct {
 	EnableSubstitutions bool          `json:"enable_substitutions"`
 	EnableRateLimiting  bool          `json:"enable_rate_limiting"`
 	RateLimitThreshold  float64       `json:"rate_limit_threshold"` // Requests per day
+
+	// Temporal pattern detection
+	EnableTemporalPatterns bool          `json:"enable_temporal_patterns"`
+	PeakShareThreshold     float64       `json:"peak_share_threshold"` // Share of traffic in one hour that marks it as peak
+	BurstWindow            time.Duration `json:"burst_window"`         // Bucket size for burst detection
+	BurstMultiplier        float64       `json:"burst_multiplier"`     // Bucket rate vs mean rate that counts as a burst
+	MinBurstRequests       int           `json:"min_burst_requests"`   // Ignore buckets smaller than this
+	MinRepeatedPrompts     int           `json:"min_repeated_prompts"` // Identical prompts needed to flag caching
 }
 
 // DefaultAnalysisConfig returns default configuration
@@ -89,6 +109,13 @@ func DefaultAnalysisConfig() *AnalysisConfig {
 		EnableSubstitutions: true,
 		EnableRateLimiting:  true,
 		RateLimitThreshold:  1000, // 1000 req/day
+
+		EnableTemporalPatterns: true,
+		PeakShareThreshold:     0.15,
+		BurstWindow:            time.Minute,
+		BurstMultiplier:        5.0,
+		MinBurstRequests:       20,
+		MinRepeatedPrompts:     5,
 	}
 }
 
@@ -0,0 +1,376 @@
+package pricing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const overridesConfigKey = "pricing_overrides"
+
+// ErrInvalidPrice is returned when an override is missing required fields or
+// carries negative prices.
+var ErrInvalidPrice = errors.New("invalid price")
+
+// Price describes what a provider charges for a model.
+// Input/output prices are optional; when unset the blended
+// CostPer1KTokensUSD applies to all tokens.
+type Price struct {
+	ProviderID         string    `json:"provider_id" yaml:"provider_id"`
+	ModelName          string    `json:"model_name" yaml:"model_name"`
+	CostPer1KTokensUSD float64   `json:"cost_per_1k_tokens_usd" yaml:"cost_per_1k_tokens_usd"`
+	InputPer1KUSD      float64   `json:"input_per_1k_usd,omitempty" yaml:"input_per_1k_usd,omitempty"`
+	OutputPer1KUSD     float64   `json:"output_per_1k_usd,omitempty" yaml:"output_per_1k_usd,omitempty"`
+	QualityScore       float64   `json:"quality_score" yaml:"quality_score"` // 0.0-1.0, higher is better
+	Tier               string    `json:"tier" yaml:"tier"`                   // "budget", "mid-tier", "premium"
+	Source             string    `json:"source" yaml:"-"`                    // "default", "catalog", "override"
+	UpdatedAt          time.Time `json:"updated_at" yaml:"updated_at,omitempty"`
+}
+
+// catalogFile is the on-disk format for a pricing catalog (YAML or JSON).
+type catalogFile struct {
+	UpdatedAt time.Time `json:"updated_at" yaml:"updated_at"`
+	Prices    []Price   `json:"prices" yaml:"prices"`
+}
+
+// Store persists per-deployment overrides.
+type Store interface {
+	GetConfigValue(key string) (string, bool, error)
+	SetConfigValue(key, value string) error
+}
+
+// Catalog holds model prices loaded from a catalog file plus per-deployment
+// overrides. Overrides always win over catalog entries.
+type Catalog struct {
+	mu          sync.RWMutex
+	path        string
+	fileModTime time.Time
+	entries     map[string]Price
+	overrides   map[string]Price
+	lastUpdated time.Time
+	store       Store
+}
+
+// NewCatalog creates a catalog seeded with the given prices.
+func NewCatalog(prices []Price) *Catalog {
+	c := &Catalog{
+		entries:   make(map[string]Price),
+		overrides: make(map[string]Price),
+	}
+	c.replaceEntries(prices, "default", time.Time{})
+	return c
+}
+
+// DefaultCatalog returns a catalog seeded with built-in fallback prices. These
+// are only used until a catalog file is loaded.
+func DefaultCatalog() *Catalog {
+	return NewCatalog(defaultPrices())
+}
+
+func defaultPrices() []Price {
+	return []Price{
+		// OpenAI Models
+		{ProviderID: "openai", ModelName: "gpt-4", CostPer1KTokensUSD: 0.03, QualityScore: 0.95, Tier: "premium"},
+		{ProviderID: "openai", ModelName: "gpt-4-turbo", CostPer1KTokensUSD: 0.01, QualityScore: 0.93, Tier: "premium"},
+		{ProviderID: "openai", ModelName: "gpt-3.5-turbo", CostPer1KTokensUSD: 0.002, QualityScore: 0.80, Tier: "mid-tier"},
+
+		// Anthropic Models
+		{ProviderID: "anthropic", ModelName: "claude-opus-4-5", CostPer1KTokensUSD: 0.015, QualityScore: 0.96, Tier: "premium"},
+		{ProviderID: "anthropic", ModelName: "claude-sonnet-4-5", CostPer1KTokensUSD: 0.003, QualityScore: 0.90, Tier: "premium"},
+		{ProviderID: "anthropic", ModelName: "claude-haiku-3-5", CostPer1KTokensUSD: 0.0008, QualityScore: 0.75, Tier: "budget"},
+
+		// Other providers (local/self-hosted typically cheaper)
+		{ProviderID: "local", ModelName: "llama-3", CostPer1KTokensUSD: 0.0, QualityScore: 0.70, Tier: "budget"},
+		{ProviderID: "ollama", ModelName: "mistral", CostPer1KTokensUSD: 0.0, QualityScore: 0.68, Tier: "budget"},
+	}
+}
+
+var (
+	defaultMu      sync.RWMutex
+	defaultCatalog = DefaultCatalog()
+)
+
+// Default returns the process-wide catalog used by cost estimation.
+func Default() *Catalog {
+	defaultMu.RLock()
+	defer defaultMu.RUnlock()
+	return defaultCatalog
+}
+
+// SetDefault replaces the process-wide catalog.
+func SetDefault(c *Catalog) {
+	if c == nil {
+		return
+	}
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+	defaultCatalog = c
+}
+
+func priceKey(providerID, modelName string) string {
+	return strings.ToLower(providerID) + "/" + strings.ToLower(modelName)
+}
+
+func (c *Catalog) replaceEntries(prices []Price, source string, updatedAt time.Time) {
+	entries := make(map[string]Price, len(prices))
+	for _, p := range prices {
+		if p.ProviderID == "" || p.ModelName == "" {
+			continue
+		}
+		p.Source = source
+		if p.UpdatedAt.IsZero() {
+			p.UpdatedAt = updatedAt
+		}
+		entries[priceKey(p.ProviderID, p.ModelName)] = p
+	}
+	c.entries = entries
+	c.lastUpdated = updatedAt
+}
+
+// LoadFile loads a YAML or JSON catalog file, replacing all catalog entries.
+// Overrides are preserved.
+func (c *Catalog) LoadFile(path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("failed to stat pricing catalog: %w", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read pricing catalog: %w", err)
+	}
+
+	var file catalogFile
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		err = json.Unmarshal(data, &file)
+	default:
+		err = yaml.Unmarshal(data, &file)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to parse pricing catalog %s: %w", path, err)
+	}
+
+	updatedAt := file.UpdatedAt
+	if updatedAt.IsZero() {
+		updatedAt = info.ModTime()
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.replaceEntries(file.Prices, "catalog", updatedAt)
+	c.path = path
+	c.fileModTime = info.ModTime()
+	return nil
+}
+
+// Refresh reloads the catalog file if it changed since the last load.
+// It returns true if the catalog was reloaded.
+func (c *Catalog) Refresh() (bool, error) {
+	c.mu.RLock()
+	path, modTime := c.path, c.fileModTime
+	c.mu.RUnlock()
+	if path == "" {
+		return false, nil
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return false, fmt.Errorf("failed to stat pricing catalog: %w", err)
+	}
+	if !info.ModTime().After(modTime) {
+		return false, nil
+	}
+	if err := c.LoadFile(path); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// StartRefresh polls the catalog file for changes until ctx is cancelled.
+func (c *Catalog) StartRefresh(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				reloaded, err := c.Refresh()
+				if err != nil {
+					log.Printf("[Pricing] Catalog refresh failed: %v", err)
+				} else if reloaded {
+					log.Printf("[Pricing] Reloaded pricing catalog (%d prices)", len(c.List()))
+				}
+			}
+		}
+	}()
+}
+
+// SetStore attaches a store for override persistence and loads any saved overrides.
+func (c *Catalog) SetStore(store Store) error {
+	c.mu.Lock()
+	c.store = store
+	c.mu.Unlock()
+	if store == nil {
+		return nil
+	}
+	raw, ok, err := store.GetConfigValue(overridesConfigKey)
+	if err != nil || !ok {
+		return err
+	}
+	var overrides []Price
+	if err := json.Unmarshal([]byte(raw), &overrides); err != nil {
+		return fmt.Errorf("failed to decode pricing overrides: %w", err)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, p := range overrides {
+		p.Source = "override"
+		c.overrides[priceKey(p.ProviderID, p.ModelName)] = p
+	}
+	return nil
+}
+
+// SetOverride adds or replaces a per-deployment price override.
+func (c *Catalog) SetOverride(p Price) (Price, error) {
+	if p.ProviderID == "" || p.ModelName == "" {
+		return Price{}, fmt.Errorf("%w: provider_id and model_name are required", ErrInvalidPrice)
+	}
+	if p.CostPer1KTokensUSD < 0 || p.InputPer1KUSD < 0 || p.OutputPer1KUSD < 0 {
+		return Price{}, fmt.Errorf("%w: prices must not be negative", ErrInvalidPrice)
+	}
+	p.Source = "override"
+	p.UpdatedAt = time.Now().UTC()
+
+	c.mu.Lock()
+	c.overrides[priceKey(p.ProviderID, p.ModelName)] = p
+	err := c.persistOverridesLocked()
+	c.mu.Unlock()
+	return p, err
+}
+
+// DeleteOverride removes an override; the catalog price applies again.
+func (c *Catalog) DeleteOverride(providerID, modelName string) (bool, error) {
+	key := priceKey(providerID, modelName)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.overrides[key]; !ok {
+		return false, nil
+	}
+	delete(c.overrides, key)
+	return true, c.persistOverridesLocked()
+}
+
+func (c *Catalog) persistOverridesLocked() error {
+	if c.store == nil {
+		return nil
+	}
+	overrides := make([]Price, 0, len(c.overrides))
+	for _, p := range c.overrides {
+		overrides = append(overrides, p)
+	}
+	data, err := json.Marshal(overrides)
+	if err != nil {
+		return err
+	}
+	return c.store.SetConfigValue(overridesConfigKey, string(data))
+}
+
+// List returns the effective price list (overrides applied), sorted by provider and model.
+func (c *Catalog) List() []Price {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	merged := make(map[string]Price, len(c.entries)+len(c.overrides))
+	for k, p := range c.entries {
+		merged[k] = p
+	}
+	for k, p := range c.overrides {
+		merged[k] = p
+	}
+	prices := make([]Price, 0, len(merged))
+	for _, p := range merged {
+		prices = append(prices, p)
+	}
+	sort.Slice(prices, func(i, j int) bool {
+		if prices[i].ProviderID != prices[j].ProviderID {
+			return prices[i].ProviderID < prices[j].ProviderID
+		}
+		return prices[i].ModelName < prices[j].ModelName
+	})
+	return prices
+}
+
+// Lookup finds the price for a provider/model. Exact matches win; otherwise a
+// catalog model name contained in the requested model name matches
+// (e.g. "gpt-4" matches "gpt-4-0613").
+func (c *Catalog) Lookup(providerID, modelName string) (Price, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	key := priceKey(providerID, modelName)
+	if p, ok := c.overrides[key]; ok {
+		return p, true
+	}
+	if p, ok := c.entries[key]; ok {
+		return p, true
+	}
+
+	lowerModel := strings.ToLower(modelName)
+	var best Price
+	found := false
+	for _, set := range []map[string]Price{c.overrides, c.entries} {
+		for _, p := range set {
+			if !strings.EqualFold(p.ProviderID, providerID) {
+				continue
+			}
+			name := strings.ToLower(p.ModelName)
+			if strings.Contains(lowerModel, name) && len(name) > len(best.ModelName) {
+				best = p
+				found = true
+			}
+		}
+		if found {
+			return best, true
+		}
+	}
+	return Price{}, false
+}
+
+// LastUpdated reports when the catalog file was last updated.
+func (c *Catalog) LastUpdated() time.Time {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.lastUpdated
+}
+
+// Path returns the catalog file path, if one was loaded.
+func (c *Catalog) Path() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.path
+}
+
+// EstimateCost returns the USD cost of a request. The second return value is
+// false when no price is known for the provider/model.
+func (c *Catalog) EstimateCost(providerID, modelName string, promptTokens, completionTokens int64) (float64, bool) {
+	p, ok := c.Lookup(providerID, modelName)
+	if !ok {
+		return 0, false
+	}
+	if p.InputPer1KUSD > 0 || p.OutputPer1KUSD > 0 {
+		return (float64(promptTokens)*p.InputPer1KUSD + float64(completionTokens)*p.OutputPer1KUSD) / 1000.0, true
+	}
+	return float64(promptTokens+completionTokens) * p.CostPer1KTokensUSD / 1000.0, true
+}
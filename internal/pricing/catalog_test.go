@@ -0,0 +1,154 @@
+package pricing
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type memStore struct{ kv map[string]string }
+
+func (m *memStore) GetConfigValue(key string) (string, bool, error) {
+	v, ok := m.kv[key]
+	return v, ok, nil
+}
+
+func (m *memStore) SetConfigValue(key, value string) error {
+	m.kv[key] = value
+	return nil
+}
+
+func TestLookup_ExactAndFuzzy(t *testing.T) {
+	c := DefaultCatalog()
+
+	p, ok := c.Lookup("openai", "gpt-4")
+	if !ok || p.CostPer1KTokensUSD != 0.03 {
+		t.Fatalf("exact lookup = %+v, %v", p, ok)
+	}
+
+	// The longest matching catalog name wins.
+	p, ok = c.Lookup("OpenAI", "gpt-4-turbo-2024-04-09")
+	if !ok || p.ModelName != "gpt-4-turbo" {
+		t.Fatalf("fuzzy lookup = %+v, %v", p, ok)
+	}
+
+	if _, ok := c.Lookup("unknown", "model"); ok {
+		t.Error("expected no match for unknown provider")
+	}
+}
+
+func TestLoadFile_YAMLAndJSON(t *testing.T) {
+	dir := t.TempDir()
+	yamlPath := filepath.Join(dir, "prices.yaml")
+	yamlData := `updated_at: 2026-03-01T00:00:00Z
+prices:
+  - provider_id: openai
+    model_name: gpt-4o
+    cost_per_1k_tokens_usd: 0.005
+    quality_score: 0.94
+    tier: premium
+`
+	if err := os.WriteFile(yamlPath, []byte(yamlData), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	c := DefaultCatalog()
+	if err := c.LoadFile(yamlPath); err != nil {
+		t.Fatalf("LoadFile(yaml): %v", err)
+	}
+	if len(c.List()) != 1 {
+		t.Fatalf("expected catalog to replace defaults, got %d prices", len(c.List()))
+	}
+	p, ok := c.Lookup("openai", "gpt-4o")
+	if !ok || p.Source != "catalog" || p.CostPer1KTokensUSD != 0.005 {
+		t.Fatalf("unexpected price: %+v", p)
+	}
+	if want := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC); !c.LastUpdated().Equal(want) {
+		t.Errorf("LastUpdated = %v, want %v", c.LastUpdated(), want)
+	}
+
+	jsonPath := filepath.Join(dir, "prices.json")
+	jsonData := `{"prices":[{"provider_id":"anthropic","model_name":"claude-sonnet-4-5","input_per_1k_usd":0.003,"output_per_1k_usd":0.015}]}`
+	if err := os.WriteFile(jsonPath, []byte(jsonData), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.LoadFile(jsonPath); err != nil {
+		t.Fatalf("LoadFile(json): %v", err)
+	}
+	cost, ok := c.EstimateCost("anthropic", "claude-sonnet-4-5", 1000, 1000)
+	if !ok || cost < 0.0179 || cost > 0.0181 {
+		t.Errorf("EstimateCost = %f, %v; want 0.018", cost, ok)
+	}
+	if c.LastUpdated().IsZero() {
+		t.Error("expected file mtime as LastUpdated when catalog has none")
+	}
+}
+
+func TestRefresh_ReloadsChangedFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "prices.yaml")
+	write := func(cost string, mtime time.Time) {
+		data := "prices:\n  - provider_id: local\n    model_name: llama-3\n    cost_per_1k_tokens_usd: " + cost + "\n"
+		if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(path, mtime, mtime); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	start := time.Now().Add(-time.Hour)
+	write("0.001", start)
+	c := NewCatalog(nil)
+	if err := c.LoadFile(path); err != nil {
+		t.Fatal(err)
+	}
+	if reloaded, err := c.Refresh(); err != nil || reloaded {
+		t.Fatalf("Refresh on unchanged file = %v, %v", reloaded, err)
+	}
+
+	write("0.002", start.Add(time.Minute))
+	if reloaded, err := c.Refresh(); err != nil || !reloaded {
+		t.Fatalf("Refresh on changed file = %v, %v", reloaded, err)
+	}
+	if p, _ := c.Lookup("local", "llama-3"); p.CostPer1KTokensUSD != 0.002 {
+		t.Errorf("cost after refresh = %f, want 0.002", p.CostPer1KTokensUSD)
+	}
+}
+
+func TestOverrides_PersistAndSurviveReload(t *testing.T) {
+	store := &memStore{kv: map[string]string{}}
+	c := DefaultCatalog()
+	if err := c.SetStore(store); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := c.SetOverride(Price{ProviderID: "openai", ModelName: "gpt-4", CostPer1KTokensUSD: 0.02}); err != nil {
+		t.Fatalf("SetOverride: %v", err)
+	}
+	if _, err := c.SetOverride(Price{ProviderID: "openai"}); err == nil {
+		t.Error("expected error for override without model")
+	}
+
+	p, _ := c.Lookup("openai", "gpt-4")
+	if p.Source != "override" || p.CostPer1KTokensUSD != 0.02 || p.UpdatedAt.IsZero() {
+		t.Fatalf("override not applied: %+v", p)
+	}
+
+	// A fresh catalog backed by the same store picks the override back up.
+	restored := DefaultCatalog()
+	if err := restored.SetStore(store); err != nil {
+		t.Fatal(err)
+	}
+	if p, _ := restored.Lookup("openai", "gpt-4"); p.CostPer1KTokensUSD != 0.02 {
+		t.Errorf("restored override cost = %f, want 0.02", p.CostPer1KTokensUSD)
+	}
+
+	removed, err := restored.DeleteOverride("openai", "gpt-4")
+	if err != nil || !removed {
+		t.Fatalf("DeleteOverride = %v, %v", removed, err)
+	}
+	if p, _ := restored.Lookup("openai", "gpt-4"); p.CostPer1KTokensUSD != 0.03 {
+		t.Errorf("cost after delete = %f, want catalog price 0.03", p.CostPer1KTokensUSD)
+	}
+}
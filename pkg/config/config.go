@@ -44,6 +44,7 @@ type Config struct {
 	Temporal  TemporalConfig  `yaml:"temporal" json:"temporal,omitempty"`
 	HotReload HotReloadConfig `yaml:"hot_reload" json:"hot_reload,omitempty"`
 	OpenClaw  OpenClawConfig  `yaml:"openclaw" json:"openclaw,omitempty"`
+	Pricing   PricingConfig   `yaml:"pricing" json:"pricing,omitempty"`
 
 	// JSON/User-specific configuration fields
 	Providers   []Provider     `yaml:"providers,omitempty" json:"providers"`
@@ -116,7 +117,16 @@ type ReadinessConfig struct {
 
 // DispatchConfig controls dispatcher guardrails
 type DispatchConfig struct {
-	MaxHops int `yaml:"max_hops" json:"max_hops,omitempty"`
+	MaxHops int          `yaml:"max_hops" json:"max_hops,omitempty"`
+	Review  ReviewConfig `yaml:"review" json:"review,omitempty"`
+}
+
+// ReviewConfig controls the author/reviewer loop that runs when an agent
+// signals DONE on a bead.
+type ReviewConfig struct {
+	Enabled      bool   `yaml:"enabled" json:"enabled"`
+	ReviewerRole string `yaml:"reviewer_role" json:"reviewer_role,omitempty"` // Role of the reviewing agent (default: code-reviewer)
+	MaxRounds    int    `yaml:"max_rounds" json:"max_rounds,omitempty"`       // Review rounds before the bead is blocked for a human
 }
 
 // GitConfig controls git-related settings
@@ -173,6 +183,13 @@ type CacheConfig struct {
 	RedisURL      string        `yaml:"redis_url" json:"redis_url,omitempty"` // Redis connection URL
 }
 
+// PricingConfig configures the model pricing catalog used for cost estimates
+// and budget enforcement.
+type PricingConfig struct {
+	CatalogPath     string        `yaml:"catalog_path" json:"catalog_path,omitempty"`         // YAML or JSON catalog file
+	RefreshInterval time.Duration `yaml:"refresh_interval" json:"refresh_interval,omitempty"` // How often to check the file for changes (0 = disabled)
+}
+
 // ProjectConfig represents a project configuration
 type ProjectConfig struct {
 	ID              string            `yaml:"id"`